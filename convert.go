@@ -0,0 +1,52 @@
+package linq
+
+// Helpers used by Order to sort a []interface{} known to hold a single
+// concrete type, by round-tripping through sort.Ints/Strings/Float64s.
+
+func toInts(values []interface{}) []int {
+	ints := make([]int, len(values))
+	for i, v := range values {
+		ints[i] = v.(int)
+	}
+	return ints
+}
+
+func intsToInterface(values []int) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func toStrings(values []interface{}) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = v.(string)
+	}
+	return strs
+}
+
+func stringsToInterface(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func toFloat64s(values []interface{}) []float64 {
+	floats := make([]float64, len(values))
+	for i, v := range values {
+		floats[i] = v.(float64)
+	}
+	return floats
+}
+
+func float64sToInterface(values []float64) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
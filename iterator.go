@@ -0,0 +1,69 @@
+package linq
+
+// Iterator is a pull-based cursor over a sequence of values. Calling it
+// repeatedly yields the next item until the sequence is exhausted
+// (ok == false) or it fails (err != nil), after which it must keep
+// returning the same terminal result.
+type Iterator func() (item interface{}, ok bool, err error)
+
+// FromChannel builds a Queryable backed by a channel instead of a
+// pre-materialized slice. Stateless operators (Where, Select, Take, Skip,
+// Distinct) chain directly off the channel without buffering, so
+// From(bigSlice-style sources can be replaced with a live producer and
+// still support short-circuiting via Take.
+func FromChannel(c <-chan interface{}) Queryable {
+	return Queryable{
+		iter: func() Iterator {
+			return func() (interface{}, bool, error) {
+				v, open := <-c
+				if !open {
+					return nil, false, nil
+				}
+				return v, true, nil
+			}
+		},
+	}
+}
+
+// FromIterator builds a Queryable from a caller-supplied next function,
+// for sources that are neither slices nor channels (e.g. a paginated API,
+// a file scanner). The same next function backs every pass over the
+// result, so a second pass continues where the first one stopped.
+func FromIterator(next func() (interface{}, bool, error)) Queryable {
+	if next == nil {
+		return Queryable{err: ErrNilFunc}
+	}
+	return Queryable{iter: func() Iterator { return next }}
+}
+
+// ToChannel runs q's pipeline and streams its results on the returned
+// channel, which is closed once the sequence is exhausted or fails. Errors
+// encountered while pulling the pipeline are dropped; callers that need
+// them should use Results() instead.
+func (q Queryable) ToChannel() <-chan interface{} {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		if q.err != nil {
+			return
+		}
+		next := q.iterate()
+		for {
+			v, ok, err := next()
+			if err != nil || !ok {
+				return
+			}
+			out <- v
+		}
+	}()
+	return out
+}
+
+// iterate returns an Iterator over q regardless of whether it was built
+// lazily (FromChannel/FromIterator) or from a materialized slice.
+func (q Queryable) iterate() Iterator {
+	if q.iter != nil {
+		return q.iter()
+	}
+	return sliceIterator(q.values)
+}
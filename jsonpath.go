@@ -0,0 +1,413 @@
+package linq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FromJSON streams the elements of a top-level JSON array from r,
+// decoding each one as interface{} (objects become map[string]interface{}).
+// Elements are pulled lazily one at a time via Iterator, so a pipeline
+// ending in Take(n) doesn't have to read the whole document.
+func FromJSON(r io.Reader) Queryable {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return Queryable{err: err}
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return Queryable{err: fmt.Errorf("linq: FromJSON expects a top-level JSON array, got %v", tok)}
+	}
+
+	return FromIterator(func() (interface{}, bool, error) {
+		if !dec.More() {
+			return nil, false, nil
+		}
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, false, err
+		}
+		return v, true, nil
+	})
+}
+
+// SelectPath projects every element of q through the jq-lite path
+// expression expr (dot fields, [N] indices, [*]/[] wildcards), flattening
+// every match into the result - the JSON analogue of SelectMany. Like
+// Where/Select, it stays lazy when q is built from a FromJSON/FromChannel
+// iterator, so FromJSON(r).SelectPath(...).Take(n) still only reads as
+// much of the document as n requires.
+func (q Queryable) SelectPath(expr string) (r Queryable) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	segs, err := parsePath(expr)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			var pending []interface{}
+			return func() (interface{}, bool, error) {
+				for len(pending) == 0 {
+					v, ok, err := next()
+					if err != nil || !ok {
+						return nil, false, err
+					}
+					pending, err = evalPath(v, segs)
+					if err != nil {
+						return nil, false, err
+					}
+				}
+				m := pending[0]
+				pending = pending[1:]
+				return m, true, nil
+			}
+		}
+		return r
+	}
+
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	for _, v := range q.values {
+		matches, err := evalPath(v, segs)
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.values = append(r.values, matches...)
+	}
+	return
+}
+
+// WherePath keeps the elements of q for which expr yields at least one
+// match satisfying `match OP value`, using the same coercion rules as an
+// inline [?(...)] path predicate (numeric compare only when both sides
+// parse as float64, string compare otherwise, nil only equal to nil).
+// Like SelectPath, it stays lazy over a FromJSON/FromChannel iterator.
+func (q Queryable) WherePath(expr string, op string, value interface{}) (r Queryable) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	segs, err := parsePath(expr)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	matchesValue := func(v interface{}) (bool, error) {
+		matches, err := evalPath(v, segs)
+		if err != nil {
+			return false, err
+		}
+		for _, m := range matches {
+			ok, err := comparePath(m, op, value)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			return func() (interface{}, bool, error) {
+				for {
+					v, ok, err := next()
+					if err != nil || !ok {
+						return nil, false, err
+					}
+					keep, err := matchesValue(v)
+					if err != nil {
+						return nil, false, err
+					}
+					if keep {
+						return v, true, nil
+					}
+				}
+			}
+		}
+		return r
+	}
+
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	for _, v := range q.values {
+		keep, err := matchesValue(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		if keep {
+			r.values = append(r.values, v)
+		}
+	}
+	return
+}
+
+// pathSegKind distinguishes the kinds of step a parsed path expression
+// can walk.
+type pathSegKind int
+
+const (
+	pathField pathSegKind = iota
+	pathIndex
+	pathWildcard
+	pathPredicate
+)
+
+type pathSeg struct {
+	kind  pathSegKind
+	field string      // pathField: field to descend into; pathPredicate: field to test
+	index int         // pathIndex
+	op    string      // pathPredicate: comparison operator
+	value interface{} // pathPredicate: right-hand side, already parsed (bool/float64/string)
+}
+
+// parsePath tokenizes a jq-lite path expression such as
+// ".orders[].total" or ".items[?(.active == true)]" into a sequence of
+// pathSeg steps: dotted field access, [N] indices, [*]/[] wildcards and
+// [?(.field OP value)] predicates.
+func parsePath(expr string) ([]pathSeg, error) {
+	var segs []pathSeg
+	i := 0
+	n := len(expr)
+	for i < n {
+		switch expr[i] {
+		case '.':
+			i++
+			start := i
+			for i < n && isIdentByte(expr[i]) {
+				i++
+			}
+			if i > start {
+				segs = append(segs, pathSeg{kind: pathField, field: expr[start:i]})
+			}
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("linq: unterminated '[' in path %q", expr)
+			}
+			inner := expr[i+1 : i+end]
+			i += end + 1
+			seg, err := parseBracket(inner)
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+		default:
+			return nil, fmt.Errorf("linq: unexpected character %q in path %q", expr[i], expr)
+		}
+	}
+	return segs, nil
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+func parseBracket(inner string) (pathSeg, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "" || inner == "*":
+		return pathSeg{kind: pathWildcard}, nil
+	case strings.HasPrefix(inner, "?"):
+		return parsePredicate(inner[1:])
+	default:
+		idx, err := strconv.Atoi(inner)
+		if err != nil {
+			return pathSeg{}, fmt.Errorf("linq: invalid index %q in path", inner)
+		}
+		return pathSeg{kind: pathIndex, index: idx}, nil
+	}
+}
+
+var predicateRe = regexp.MustCompile(`^\(\s*\.([A-Za-z0-9_]+)\s*(==|!=|<=|>=|<|>|=~)\s*(.+?)\s*\)$`)
+
+func parsePredicate(expr string) (pathSeg, error) {
+	m := predicateRe.FindStringSubmatch(expr)
+	if m == nil {
+		return pathSeg{}, fmt.Errorf("linq: invalid predicate %q, want ?(.field OP value)", expr)
+	}
+	return pathSeg{kind: pathPredicate, field: m[1], op: m[2], value: parseScalar(m[3])}, nil
+}
+
+// parseScalar interprets a literal appearing on the right-hand side of a
+// [?(...)] path predicate: "true"/"false", nil for "null", a float64 if it
+// parses as a number, an unquoted or quoted string otherwise. WherePath's
+// value argument is used as given and does not pass through here.
+func parseScalar(lit string) interface{} {
+	lit = strings.TrimSpace(lit)
+	switch lit {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if len(lit) >= 2 && lit[0] == '"' && lit[len(lit)-1] == '"' {
+		return lit[1 : len(lit)-1]
+	}
+	if f, err := strconv.ParseFloat(lit, 64); err == nil {
+		return f
+	}
+	return lit
+}
+
+// evalPath walks value through segs, returning every match. A field or
+// index step that doesn't exist simply drops that branch; a wildcard or
+// predicate fans a single value out into zero or more.
+func evalPath(value interface{}, segs []pathSeg) ([]interface{}, error) {
+	cur := []interface{}{value}
+	for _, seg := range segs {
+		var next []interface{}
+		for _, v := range cur {
+			matches, err := applySeg(v, seg)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, matches...)
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func applySeg(v interface{}, seg pathSeg) ([]interface{}, error) {
+	switch seg.kind {
+	case pathField:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		if val, ok := m[seg.field]; ok {
+			return []interface{}{val}, nil
+		}
+		return nil, nil
+	case pathIndex:
+		arr, ok := v.([]interface{})
+		if !ok || seg.index < 0 || seg.index >= len(arr) {
+			return nil, nil
+		}
+		return []interface{}{arr[seg.index]}, nil
+	case pathWildcard:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return arr, nil
+	case pathPredicate:
+		arr, ok := v.([]interface{})
+		if !ok {
+			arr = []interface{}{v}
+		}
+		var kept []interface{}
+		for _, item := range arr {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			match, err := comparePath(m[seg.field], seg.op, seg.value)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				kept = append(kept, item)
+			}
+		}
+		return kept, nil
+	default:
+		return nil, fmt.Errorf("linq: unknown path segment kind %d", seg.kind)
+	}
+}
+
+// comparePath implements the jq-basic-filter coercion rules: numeric
+// compare only when both sides parse as float64, string compare
+// otherwise, and nil only equals nil.
+func comparePath(a interface{}, op string, b interface{}) (bool, error) {
+	if op == "=~" {
+		as, aok := a.(string)
+		bs, bok := b.(string)
+		if !aok || !bok {
+			return false, nil
+		}
+		re, err := regexp.Compile(bs)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(as), nil
+	}
+
+	if a == nil || b == nil {
+		eq := a == nil && b == nil
+		switch op {
+		case "==":
+			return eq, nil
+		case "!=":
+			return !eq, nil
+		default:
+			return false, nil
+		}
+	}
+
+	asFloat := func(x interface{}) (float64, bool) {
+		if s, ok := x.(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			return f, err == nil
+		}
+		return toFloat64(x)
+	}
+	if af, aok := asFloat(a); aok {
+		if bf, bok := asFloat(b); bok {
+			return compareOrdered(af, op, bf)
+		}
+	}
+
+	as := fmt.Sprintf("%v", a)
+	bs := fmt.Sprintf("%v", b)
+	return compareOrdered(as, op, bs)
+}
+
+// compareOrdered evaluates a relational or equality operator for any
+// naturally ordered type, used once both sides of a path predicate have
+// been coerced to the same kind (float64 or string).
+func compareOrdered[T int | float64 | string](a T, op string, b T) (bool, error) {
+	switch op {
+	case "==":
+		return a == b, nil
+	case "!=":
+		return a != b, nil
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	case ">":
+		return a > b, nil
+	case ">=":
+		return a >= b, nil
+	default:
+		return false, fmt.Errorf("linq: unsupported operator %q", op)
+	}
+}
@@ -1,40 +1,135 @@
 package linq
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"sort"
 )
 
 type Queryable struct {
 	values []interface{}
 	err    error
-	less   func(this, that interface{}) bool
+	iter   func() Iterator
+
+	// Parallel execution settings, toggled by Parallel()/AsUnordered()/
+	// WithDegreeOfParallelism()/WithContext(). Once Parallel() is on, Where,
+	// Select and Distinct carry them forward onto their result; the other
+	// operators (Take, Skip, DistinctBy, OrderBy, GroupBy, ...) return a
+	// Queryable with them cleared.
+	parallel  bool
+	unordered bool
+	degree    int
+	ctx       context.Context
 }
 
-func (q Queryable) Len() int           { return len(q.values) }
-func (q Queryable) Swap(i, j int)      { q.values[i], q.values[j] = q.values[j], q.values[i] }
-func (q Queryable) Less(i, j int) bool { return q.less(q.values[i], q.values[j]) }
+// carryParallel copies q's parallel-execution settings onto r, so that
+// e.g. q.Parallel().Where(...).Select(...) keeps running in parallel
+// without having to call Parallel() again at every stage.
+func (q Queryable) carryParallel(r Queryable) Queryable {
+	r.parallel = q.parallel
+	r.unordered = q.unordered
+	r.degree = q.degree
+	r.ctx = q.ctx
+	return r
+}
 
 var (
-	ErrNilFunc         = errors.New("linq: passed evaluation function is nil")
-	ErrNilInput        = errors.New("linq: nil input passed to From")
-	ErrNoElement       = errors.New("linq: element satisfying the conditions does not exist")
-	ErrNegativeParam   = errors.New("linq: parameter cannot be negative")
-	ErrUnsupportedType = errors.New("linq: sorting this type with Order is not supported, use OrderBy")
+	ErrNilFunc            = errors.New("linq: passed evaluation function is nil")
+	ErrNilInput           = errors.New("linq: nil input passed to From")
+	ErrNoElement          = errors.New("linq: element satisfying the conditions does not exist")
+	ErrNegativeParam      = errors.New("linq: parameter cannot be negative")
+	ErrUnsupportedType    = errors.New("linq: unsupported type for this operation")
+	ErrInvalidDestination = errors.New("linq: ToSlice destination must be a non-nil pointer to a slice")
 )
 
-func From(input []interface{}) Queryable {
-	var _err error
+// From accepts a slice, array, map or <-chan T and wraps it as a
+// Queryable, converting elements to interface{} via reflection. Passing
+// a map yields a sequence of KeyValue pairs, one per entry. A channel
+// source is read lazily (see FromChannel) so Take can still short-circuit
+// it. Anything else - including nil - returns a Queryable carrying
+// ErrUnsupportedType/ErrNilInput.
+func From(input interface{}) Queryable {
 	if input == nil {
-		_err = ErrNilInput
+		return Queryable{err: ErrNilInput}
+	}
+
+	v := reflect.ValueOf(input)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+		return Queryable{values: values}
+	case reflect.Map:
+		values := make([]interface{}, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values = append(values, KeyValue{Key: iter.Key().Interface(), Value: iter.Value().Interface()})
+		}
+		return Queryable{values: values}
+	case reflect.Chan:
+		return FromChannelOf(v)
+	default:
+		return Queryable{err: ErrUnsupportedType}
 	}
-	return Queryable{
-		values: input,
-		err:    _err}
 }
 
 func (q Queryable) Results() ([]interface{}, error) {
-	return q.values, q.err
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.iter == nil {
+		return q.values, nil
+	}
+	return drain(q.iter())
+}
+
+// materialize pulls q into a plain slice of values, running its iterator
+// to completion if q was built lazily. Operators that cannot be expressed
+// as a simple iterator stage (Union, GroupBy, Order, ...) call this to fall
+// back to the eager path.
+func (q Queryable) materialize() (r Queryable) {
+	r = q.carryParallel(r)
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if q.iter == nil {
+		r.values = q.values
+		return
+	}
+	r.values, r.err = drain(q.iter())
+	return
+}
+
+// drain pulls an Iterator to exhaustion into a slice, stopping early on
+// the first error.
+func drain(next Iterator) (values []interface{}, err error) {
+	for {
+		v, ok, e := next()
+		if e != nil {
+			return nil, e
+		}
+		if !ok {
+			return values, nil
+		}
+		values = append(values, v)
+	}
+}
+
+// sliceIterator returns an Iterator that walks a pre-materialized slice.
+func sliceIterator(values []interface{}) Iterator {
+	i := 0
+	return func() (interface{}, bool, error) {
+		if i >= len(values) {
+			return nil, false, nil
+		}
+		v := values[i]
+		i++
+		return v, true, nil
+	}
 }
 
 func (q Queryable) Where(f func(interface{}) (bool, error)) (r Queryable) {
@@ -47,17 +142,43 @@ func (q Queryable) Where(f func(interface{}) (bool, error)) (r Queryable) {
 		return
 	}
 
-	for _, i := range q.values {
-		ok, err := f(i)
-		if err != nil {
-			r.err = err // TODO add extra messages
-			return r
+	if q.parallel {
+		q = q.materialize()
+		if q.err != nil {
+			r.err = q.err
+			return
 		}
-		if ok {
-			r.values = append(r.values, i)
+		return q.carryParallel(q.parallelWhere(f))
+	}
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			return func() (interface{}, bool, error) {
+				for {
+					v, ok, err := next()
+					if err != nil || !ok {
+						return nil, false, err
+					}
+					matched, err := f(v)
+					if err != nil {
+						return nil, false, err
+					}
+					if matched {
+						return v, true, nil
+					}
+				}
+			}
 		}
+		return r
 	}
-	return r
+
+	// Eager path delegates to the generic core: Queryable's predicate
+	// signature is exactly Query[interface{}].WhereErr's.
+	g := q.asQuery().WhereErr(f)
+	r.values, r.err = g.values, g.err
+	return q.carryParallel(r)
 }
 
 func (q Queryable) Select(f func(interface{}) (interface{}, error)) (r Queryable) {
@@ -70,15 +191,38 @@ func (q Queryable) Select(f func(interface{}) (interface{}, error)) (r Queryable
 		return
 	}
 
-	for _, i := range q.values {
-		val, err := f(i)
-		if err != nil {
-			r.err = err // TODO add extra messages
-			return r
+	if q.parallel {
+		q = q.materialize()
+		if q.err != nil {
+			r.err = q.err
+			return
 		}
-		r.values = append(r.values, val)
+		return q.carryParallel(q.parallelSelect(f))
 	}
-	return
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			return func() (interface{}, bool, error) {
+				v, ok, err := next()
+				if err != nil || !ok {
+					return nil, false, err
+				}
+				val, err := f(v)
+				if err != nil {
+					return nil, false, err
+				}
+				return val, true, nil
+			}
+		}
+		return r
+	}
+
+	// Eager path delegates to the generic core, same reasoning as Where.
+	g := SelectErr[interface{}, interface{}](q.asQuery(), f)
+	r.values, r.err = g.values, g.err
+	return q.carryParallel(r)
 }
 
 func (q Queryable) Distinct() (r Queryable) {
@@ -100,6 +244,36 @@ func (q Queryable) distinct(f func(interface{}, interface{}) (bool, error)) (r Q
 	}
 
 	if f == nil {
+		if q.parallel {
+			q = q.materialize()
+			if q.err != nil {
+				r.err = q.err
+				return
+			}
+			return q.carryParallel(q.parallelDistinct())
+		}
+		if q.iter != nil {
+			// basic equality comparison using a running hash set, pulled
+			// lazily so a downstream Take can still short-circuit upstream
+			src := q.iter
+			r.iter = func() Iterator {
+				next := src()
+				seen := make(map[interface{}]bool)
+				return func() (interface{}, bool, error) {
+					for {
+						v, ok, err := next()
+						if err != nil || !ok {
+							return nil, false, err
+						}
+						if !seen[v] {
+							seen[v] = true
+							return v, true, nil
+						}
+					}
+				}
+			}
+			return r
+		}
 		// basic equality comparison using dict
 		dict := make(map[interface{}]bool)
 		for _, v := range q.values {
@@ -115,6 +289,11 @@ func (q Queryable) distinct(f func(interface{}, interface{}) (bool, error)) (r Q
 		}
 		r.values = res
 	} else {
+		q = q.materialize()
+		if q.err != nil {
+			r.err = q.err
+			return
+		}
 		// use equality comparer and bool flags for each item
 		// here we check all a[i]==a[j] i<j, practically worst case
 		// for this is O(N^2) where all elements are different and best case
@@ -145,6 +324,7 @@ func (q Queryable) distinct(f func(interface{}, interface{}) (bool, error)) (r Q
 }
 
 func (q Queryable) Union(in []interface{}) (r Queryable) {
+	q = q.materialize()
 	if q.err != nil {
 		r.err = q.err
 		return
@@ -171,6 +351,7 @@ func (q Queryable) Union(in []interface{}) (r Queryable) {
 }
 
 func (q Queryable) Intersect(in []interface{}) (r Queryable) {
+	q = q.materialize()
 	if q.err != nil {
 		r.err = q.err
 		return
@@ -201,6 +382,7 @@ func (q Queryable) Intersect(in []interface{}) (r Queryable) {
 }
 
 func (q Queryable) Except(except []interface{}) (r Queryable) {
+	q = q.materialize()
 	if q.err != nil {
 		r.err = q.err
 		return
@@ -225,10 +407,22 @@ func (q Queryable) Except(except []interface{}) (r Queryable) {
 }
 
 func (q Queryable) Count() (count int, err error) {
-	return len(q.values), q.err
+	q = q.materialize()
+	return q.asQuery().Count()
+}
+
+// asQuery lifts q's materialized values and error onto the generic core
+// (Query[interface{}]), so operators that overlap between the two APIs
+// - Where, Select, OrderBy, Count, First - share one implementation
+// instead of two hand-maintained copies. q must already be materialized;
+// the lazy-iterator and Parallel() paths have no generic-core
+// counterpart and stay Queryable-specific.
+func (q Queryable) asQuery() Query[interface{}] {
+	return Query[interface{}]{values: q.values, err: q.err}
 }
 
 func (q Queryable) CountBy(f func(interface{}) (bool, error)) (c int, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -237,6 +431,9 @@ func (q Queryable) CountBy(f func(interface{}) (bool, error)) (c int, err error)
 		err = ErrNilFunc
 		return
 	}
+	if q.parallel {
+		return q.parallelCountBy(f)
+	}
 
 	for _, i := range q.values {
 		ok, e := f(i)
@@ -252,10 +449,12 @@ func (q Queryable) CountBy(f func(interface{}) (bool, error)) (c int, err error)
 }
 
 func (q Queryable) Any() (exists bool, err error) {
+	q = q.materialize()
 	return len(q.values) > 0, q.err
 }
 
 func (q Queryable) AnyWith(f func(interface{}) (bool, error)) (exists bool, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -264,6 +463,9 @@ func (q Queryable) AnyWith(f func(interface{}) (bool, error)) (exists bool, err
 		err = ErrNilFunc
 		return
 	}
+	if q.parallel {
+		return q.parallelAnyWith(f)
+	}
 
 	for _, i := range q.values {
 		ok, e := f(i)
@@ -280,6 +482,7 @@ func (q Queryable) AnyWith(f func(interface{}) (bool, error)) (exists bool, err
 }
 
 func (q Queryable) All(f func(interface{}) (bool, error)) (all bool, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -288,6 +491,9 @@ func (q Queryable) All(f func(interface{}) (bool, error)) (all bool, err error)
 		err = ErrNilFunc
 		return
 	}
+	if q.parallel {
+		return q.parallelAll(f)
+	}
 
 	all = true // if no elements, result is true
 	for _, i := range q.values {
@@ -302,6 +508,7 @@ func (q Queryable) All(f func(interface{}) (bool, error)) (all bool, err error)
 }
 
 func (q Queryable) Single(f func(interface{}) (bool, error)) (single bool, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -320,19 +527,12 @@ func (q Queryable) Single(f func(interface{}) (bool, error)) (single bool, err e
 }
 
 func (q Queryable) First() (elem interface{}, err error) {
-	if q.err != nil {
-		err = q.err
-		return
-	}
-	if len(q.values) == 0 {
-		err = ErrNoElement
-	} else {
-		elem = q.values[0]
-	}
-	return
+	q = q.materialize()
+	return q.asQuery().First()
 }
 
 func (q Queryable) FirstOrNil() (elem interface{}, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -343,6 +543,9 @@ func (q Queryable) FirstOrNil() (elem interface{}, err error) {
 	return
 }
 
+// firstBy pulls q one element at a time and stops as soon as f matches,
+// so a lazily-built Queryable (FromChannel/FromIterator) doesn't have to
+// be drained upstream of the match.
 func (q Queryable) firstBy(f func(interface{}) (bool, error)) (elem interface{}, found bool, err error) {
 	if q.err != nil {
 		err = q.err
@@ -352,19 +555,27 @@ func (q Queryable) firstBy(f func(interface{}) (bool, error)) (elem interface{},
 		err = ErrNilFunc
 		return
 	}
-	for _, i := range q.values {
-		ok, e := f(i)
+	next := q.iterate()
+	for {
+		i, ok, e := next()
 		if e != nil {
 			err = e // TODO add extra messages
 			return
 		}
-		if ok {
+		if !ok {
+			return
+		}
+		matched, e := f(i)
+		if e != nil {
+			err = e // TODO add extra messages
+			return
+		}
+		if matched {
 			elem = i
 			found = true
-			break
+			return
 		}
 	}
-	return
 }
 
 func (q Queryable) FirstBy(f func(interface{}) (bool, error)) (elem interface{}, err error) {
@@ -386,6 +597,7 @@ func (q Queryable) FirstOrNilBy(f func(interface{}) (bool, error)) (elem interfa
 }
 
 func (q Queryable) Last() (elem interface{}, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -399,6 +611,7 @@ func (q Queryable) Last() (elem interface{}, err error) {
 }
 
 func (q Queryable) LastOrNil() (elem interface{}, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -410,6 +623,7 @@ func (q Queryable) LastOrNil() (elem interface{}, err error) {
 }
 
 func (q Queryable) lastBy(f func(interface{}) (bool, error)) (elem interface{}, found bool, err error) {
+	q = q.materialize()
 	if q.err != nil {
 		err = q.err
 		return
@@ -452,7 +666,11 @@ func (q Queryable) LastOrNilBy(f func(interface{}) (bool, error)) (elem interfac
 	return
 }
 
+// Reverse is a blocking operator: the whole sequence has to be buffered
+// before the last element is known, so a lazily-built Queryable is
+// materialized first.
 func (q Queryable) Reverse() (r Queryable) {
+	q = q.materialize()
 	if q.err != nil {
 		r.err = q.err
 		return
@@ -475,6 +693,27 @@ func (q Queryable) Take(n int) (r Queryable) {
 	if n < 0 {
 		n = 0
 	}
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			taken := 0
+			return func() (interface{}, bool, error) {
+				if taken >= n {
+					return nil, false, nil
+				}
+				v, ok, err := next()
+				if err != nil || !ok {
+					return nil, false, err
+				}
+				taken++
+				return v, true, nil
+			}
+		}
+		return r
+	}
+
 	if n >= len(q.values) {
 		n = len(q.values)
 	}
@@ -490,6 +729,26 @@ func (q Queryable) Skip(n int) (r Queryable) {
 	if n < 0 {
 		n = 0
 	}
+
+	if q.iter != nil {
+		src := q.iter
+		r.iter = func() Iterator {
+			next := src()
+			skipped := 0
+			return func() (interface{}, bool, error) {
+				for skipped < n {
+					_, ok, err := next()
+					if err != nil || !ok {
+						return nil, false, err
+					}
+					skipped++
+				}
+				return next()
+			}
+		}
+		return r
+	}
+
 	if n >= len(q.values) {
 		n = len(q.values)
 	}
@@ -497,8 +756,9 @@ func (q Queryable) Skip(n int) (r Queryable) {
 	return
 }
 
-//TODO document: only sorts int, string, float64
+// TODO document: only sorts int, string, float64
 func (q Queryable) Order() (r Queryable) {
+	q = q.materialize()
 	if q.err != nil {
 		r.err = q.err
 		return
@@ -525,18 +785,11 @@ func (q Queryable) Order() (r Queryable) {
 	return
 }
 
+// OrderBy delegates to the generic core (Query[interface{}].OrderBy),
+// which is a stable sort.
 func (q Queryable) OrderBy(less func(this interface{}, that interface{}) bool) (r Queryable) {
-	if q.err != nil {
-		r.err = q.err
-		return
-	}
-	if less == nil {
-		r.err = ErrNilFunc
-		return
-	}
-	r.less = less
-	r.values = make([]interface{}, len(q.values))
-	_ = copy(r.values, q.values)
-	sort.Sort(r)
+	q = q.materialize()
+	g := q.asQuery().OrderBy(less)
+	r.values, r.err = g.values, g.err
 	return
-}
\ No newline at end of file
+}
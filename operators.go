@@ -0,0 +1,347 @@
+package linq
+
+// Group is a single GroupBy bucket: every element of Values produced the
+// same Key.
+type Group struct {
+	Key    interface{}
+	Values []interface{}
+}
+
+// GroupBy partitions q's elements by the key keySelector extracts,
+// preserving the order in which each key first appears. The result is a
+// Queryable of Group values.
+func (q Queryable) GroupBy(keySelector func(interface{}) (interface{}, error)) (r Queryable) {
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if keySelector == nil {
+		r.err = ErrNilFunc
+		return
+	}
+
+	index := make(map[interface{}]int)
+	groups := make([]*Group, 0)
+	for _, v := range q.values {
+		k, err := keySelector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		if i, ok := index[k]; ok {
+			groups[i].Values = append(groups[i].Values, v)
+			continue
+		}
+		index[k] = len(groups)
+		groups = append(groups, &Group{Key: k, Values: []interface{}{v}})
+	}
+	r.values = make([]interface{}, len(groups))
+	for i, g := range groups {
+		r.values[i] = *g
+	}
+	return
+}
+
+// SelectMany projects each element of q into a slice via selector and
+// flattens the results into a single Queryable, in order.
+func (q Queryable) SelectMany(selector func(interface{}) ([]interface{}, error)) (r Queryable) {
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if selector == nil {
+		r.err = ErrNilFunc
+		return
+	}
+
+	for _, v := range q.values {
+		inner, err := selector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.values = append(r.values, inner...)
+	}
+	return
+}
+
+// Join correlates each element of q with the elements of inner that
+// share a key, in the style of an inner join: outerKeySelector and
+// innerKeySelector extract the comparison key from each side, and
+// resultSelector combines a matching pair into the output value. Outer
+// elements with no match are dropped.
+func (q Queryable) Join(inner []interface{},
+	outerKeySelector func(interface{}) (interface{}, error),
+	innerKeySelector func(interface{}) (interface{}, error),
+	resultSelector func(outer, inner interface{}) (interface{}, error)) (r Queryable) {
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if outerKeySelector == nil || innerKeySelector == nil || resultSelector == nil {
+		r.err = ErrNilFunc
+		return
+	}
+
+	innerByKey := make(map[interface{}][]interface{})
+	for _, v := range inner {
+		k, err := innerKeySelector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		innerByKey[k] = append(innerByKey[k], v)
+	}
+
+	for _, v := range q.values {
+		k, err := outerKeySelector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		for _, iv := range innerByKey[k] {
+			res, err := resultSelector(v, iv)
+			if err != nil {
+				r.err = err
+				return
+			}
+			r.values = append(r.values, res)
+		}
+	}
+	return
+}
+
+// GroupJoin correlates each element of q with *all* matching elements of
+// inner at once, passing resultSelector the outer element alongside the
+// (possibly empty) slice of inner matches. Unlike Join, outer elements
+// with no match are kept.
+func (q Queryable) GroupJoin(inner []interface{},
+	outerKeySelector func(interface{}) (interface{}, error),
+	innerKeySelector func(interface{}) (interface{}, error),
+	resultSelector func(outer interface{}, inners []interface{}) (interface{}, error)) (r Queryable) {
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if outerKeySelector == nil || innerKeySelector == nil || resultSelector == nil {
+		r.err = ErrNilFunc
+		return
+	}
+
+	innerByKey := make(map[interface{}][]interface{})
+	for _, v := range inner {
+		k, err := innerKeySelector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		innerByKey[k] = append(innerByKey[k], v)
+	}
+
+	for _, v := range q.values {
+		k, err := outerKeySelector(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		res, err := resultSelector(v, innerByKey[k])
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.values = append(r.values, res)
+	}
+	return
+}
+
+// Zip combines q with other pairwise via resultSelector, stopping as
+// soon as the shorter of the two sequences is exhausted.
+func (q Queryable) Zip(other []interface{}, resultSelector func(a, b interface{}) (interface{}, error)) (r Queryable) {
+	q = q.materialize()
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if resultSelector == nil {
+		r.err = ErrNilFunc
+		return
+	}
+
+	n := len(q.values)
+	if len(other) < n {
+		n = len(other)
+	}
+	r.values = make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		v, err := resultSelector(q.values[i], other[i])
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.values[i] = v
+	}
+	return
+}
+
+// Aggregate reduces q to a single value by threading seed through
+// accumulator for every element, left to right.
+func (q Queryable) Aggregate(seed interface{}, accumulator func(acc, v interface{}) (interface{}, error)) (result interface{}, err error) {
+	q = q.materialize()
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	if accumulator == nil {
+		err = ErrNilFunc
+		return
+	}
+	if q.parallel {
+		return q.parallelAggregate(seed, accumulator)
+	}
+
+	acc := seed
+	for _, v := range q.values {
+		acc, err = accumulator(acc, v)
+		if err != nil {
+			return
+		}
+	}
+	result = acc
+	return
+}
+
+// Sum adds up q's elements, which must all be int or float64, returning
+// a float64. Use Average instead if you want the mean.
+func (q Queryable) Sum() (sum float64, err error) {
+	q = q.materialize()
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	for _, v := range q.values {
+		f, ok := toFloat64(v)
+		if !ok {
+			err = ErrUnsupportedType
+			return
+		}
+		sum += f
+	}
+	return
+}
+
+// Average returns the arithmetic mean of q's elements, which must all be
+// int or float64. It returns ErrNoElement for an empty sequence.
+func (q Queryable) Average() (avg float64, err error) {
+	q = q.materialize()
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	if len(q.values) == 0 {
+		err = ErrNoElement
+		return
+	}
+	sum, err := q.Sum()
+	if err != nil {
+		return
+	}
+	avg = sum / float64(len(q.values))
+	return
+}
+
+// Min returns the smallest of q's elements, which must all be int or
+// float64. Use MinBy for other types or derived comparison keys.
+func (q Queryable) Min() (min float64, err error) {
+	return q.extremum(func(a, b float64) bool { return a < b })
+}
+
+// Max returns the largest of q's elements, which must all be int or
+// float64. Use MaxBy for other types or derived comparison keys.
+func (q Queryable) Max() (max float64, err error) {
+	return q.extremum(func(a, b float64) bool { return a > b })
+}
+
+func (q Queryable) extremum(better func(a, b float64) bool) (result float64, err error) {
+	q = q.materialize()
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	if len(q.values) == 0 {
+		err = ErrNoElement
+		return
+	}
+	for i, v := range q.values {
+		f, ok := toFloat64(v)
+		if !ok {
+			err = ErrUnsupportedType
+			return
+		}
+		if i == 0 || better(f, result) {
+			result = f
+		}
+	}
+	return
+}
+
+// MinBy returns the element of q for which key is smallest, comparing
+// keys with less (this-sorts-before-that, same convention as OrderBy).
+func (q Queryable) MinBy(key func(interface{}) (interface{}, error), less func(this, that interface{}) bool) (elem interface{}, err error) {
+	return q.extremumBy(key, less)
+}
+
+// MaxBy returns the element of q for which key is largest.
+func (q Queryable) MaxBy(key func(interface{}) (interface{}, error), less func(this, that interface{}) bool) (elem interface{}, err error) {
+	return q.extremumBy(key, func(this, that interface{}) bool { return less(that, this) })
+}
+
+func (q Queryable) extremumBy(key func(interface{}) (interface{}, error), less func(this, that interface{}) bool) (elem interface{}, err error) {
+	q = q.materialize()
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	if key == nil || less == nil {
+		err = ErrNilFunc
+		return
+	}
+	if len(q.values) == 0 {
+		err = ErrNoElement
+		return
+	}
+
+	bestKey, err := key(q.values[0])
+	if err != nil {
+		return
+	}
+	elem = q.values[0]
+	for _, v := range q.values[1:] {
+		k, e := key(v)
+		if e != nil {
+			err = e
+			return
+		}
+		if less(k, bestKey) {
+			bestKey = k
+			elem = v
+		}
+	}
+	return
+}
+
+// toFloat64 converts the numeric interface{} kinds linq understands
+// (int, float64) to float64, reporting false for anything else.
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,308 @@
+package linq
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Parallel turns on PLINQ-style fan-out for the operators that support it
+// (Where, Select, Distinct, CountBy, All, AnyWith, Aggregate). Input is
+// split into indexed chunks processed by a worker pool, and results are
+// reassembled in the original order.
+func (q Queryable) Parallel() (r Queryable) {
+	r = q
+	r.parallel = true
+	return
+}
+
+// AsUnordered records that the caller doesn't need the result in input
+// order, so a future implementation can merge chunks as they finish
+// instead of reassembling by index. The current worker pool always
+// preserves order regardless; this is the opt-out hook for that.
+func (q Queryable) AsUnordered() (r Queryable) {
+	r = q
+	r.unordered = true
+	return
+}
+
+// WithDegreeOfParallelism sets the worker pool size used by Parallel().
+// It defaults to runtime.NumCPU().
+func (q Queryable) WithDegreeOfParallelism(n int) (r Queryable) {
+	r = q
+	r.degree = n
+	return
+}
+
+// WithContext threads ctx through the parallel pipeline so long-running
+// workers can be cancelled, and so the first worker error cancels the
+// rest instead of letting them run to completion.
+func (q Queryable) WithContext(ctx context.Context) (r Queryable) {
+	r = q
+	r.ctx = ctx
+	return
+}
+
+func (q Queryable) degreeOfParallelism() int {
+	if q.degree > 0 {
+		return q.degree
+	}
+	return runtime.NumCPU()
+}
+
+// parallelChunk is one slice of the original input paired with its
+// starting offset, so results can be written back in order.
+type parallelChunk struct {
+	offset int
+	values []interface{}
+}
+
+func chunk(values []interface{}, n int) []parallelChunk {
+	if n < 1 {
+		n = 1
+	}
+	size := (len(values) + n - 1) / n
+	if size < 1 {
+		size = 1
+	}
+	chunks := make([]parallelChunk, 0, n)
+	for offset := 0; offset < len(values); offset += size {
+		end := offset + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, parallelChunk{offset: offset, values: values[offset:end]})
+	}
+	return chunks
+}
+
+// runParallel applies work to every chunk of q.values concurrently,
+// bounded by q.degreeOfParallelism(), stopping early on the first error.
+// work must not mutate its input chunk.
+func (q Queryable) runParallel(work func(ctx context.Context, c parallelChunk) (interface{}, error)) (results []interface{}, err error) {
+	ctx := q.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := chunk(q.values, q.degreeOfParallelism())
+	results = make([]interface{}, len(chunks))
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	for i, c := range chunks {
+		wg.Add(1)
+		go func(i int, c parallelChunk) {
+			defer wg.Done()
+			res, e := work(ctx, c)
+			if e != nil {
+				once.Do(func() {
+					err = e
+					cancel()
+				})
+				return
+			}
+			results[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+	return
+}
+
+func (q Queryable) parallelWhere(f func(interface{}) (bool, error)) (r Queryable) {
+	perChunk, err := q.runParallel(func(ctx context.Context, c parallelChunk) (interface{}, error) {
+		kept := make([]interface{}, 0, len(c.values))
+		for _, v := range c.values {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ok, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				kept = append(kept, v)
+			}
+		}
+		return kept, nil
+	})
+	if err != nil {
+		r.err = err
+		return
+	}
+	for _, part := range perChunk {
+		r.values = append(r.values, part.([]interface{})...)
+	}
+	return
+}
+
+func (q Queryable) parallelSelect(f func(interface{}) (interface{}, error)) (r Queryable) {
+	perChunk, err := q.runParallel(func(ctx context.Context, c parallelChunk) (interface{}, error) {
+		mapped := make([]interface{}, len(c.values))
+		for i, v := range c.values {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			val, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			mapped[i] = val
+		}
+		return mapped, nil
+	})
+	if err != nil {
+		r.err = err
+		return
+	}
+	for _, part := range perChunk {
+		r.values = append(r.values, part.([]interface{})...)
+	}
+	return
+}
+
+func (q Queryable) parallelDistinct() (r Queryable) {
+	perChunk, err := q.runParallel(func(ctx context.Context, c parallelChunk) (interface{}, error) {
+		seen := make(map[interface{}]bool, len(c.values))
+		local := make([]interface{}, 0, len(c.values))
+		for _, v := range c.values {
+			if !seen[v] {
+				seen[v] = true
+				local = append(local, v)
+			}
+		}
+		return local, nil
+	})
+	if err != nil {
+		r.err = err
+		return
+	}
+	seen := make(map[interface{}]bool)
+	for _, part := range perChunk {
+		for _, v := range part.([]interface{}) {
+			if !seen[v] {
+				seen[v] = true
+				r.values = append(r.values, v)
+			}
+		}
+	}
+	return
+}
+
+func (q Queryable) parallelCountBy(f func(interface{}) (bool, error)) (c int, err error) {
+	perChunk, err := q.runParallel(func(ctx context.Context, ch parallelChunk) (interface{}, error) {
+		count := 0
+		for _, v := range ch.values {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ok, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				count++
+			}
+		}
+		return count, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, part := range perChunk {
+		c += part.(int)
+	}
+	return
+}
+
+func (q Queryable) parallelAll(f func(interface{}) (bool, error)) (all bool, err error) {
+	perChunk, err := q.runParallel(func(ctx context.Context, ch parallelChunk) (interface{}, error) {
+		for _, v := range ch.values {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ok, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	all = true
+	for _, part := range perChunk {
+		all = all && part.(bool)
+	}
+	return
+}
+
+func (q Queryable) parallelAnyWith(f func(interface{}) (bool, error)) (exists bool, err error) {
+	perChunk, err := q.runParallel(func(ctx context.Context, ch parallelChunk) (interface{}, error) {
+		for _, v := range ch.values {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ok, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, part := range perChunk {
+		if part.(bool) {
+			exists = true
+			break
+		}
+	}
+	return
+}
+
+// parallelAggregate assumes accumulator is associative: each chunk is
+// reduced independently (seeded with the chunk's own first element, not
+// the caller's seed) and the partial results are combined, in chunk
+// order, starting from seed. seed is applied exactly once this way -
+// folding it into every chunk's reduction as well would double-count it
+// for any accumulator where seed isn't the identity value (e.g. seed=100,
+// add: sequential gives 110, but seeding every chunk too gives 310).
+// chunk() never hands back an empty parallelChunk, so ch.values[0] is safe.
+func (q Queryable) parallelAggregate(seed interface{}, accumulator func(acc, v interface{}) (interface{}, error)) (result interface{}, err error) {
+	perChunk, err := q.runParallel(func(ctx context.Context, ch parallelChunk) (interface{}, error) {
+		acc := ch.values[0]
+		for _, v := range ch.values[1:] {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			var e error
+			acc, e = accumulator(acc, v)
+			if e != nil {
+				return nil, e
+			}
+		}
+		return acc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = seed
+	for _, part := range perChunk {
+		result, err = accumulator(result, part)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package linq
+
+import "testing"
+
+// TestParallelAggregateSeedAppliedOnce pins down that Aggregate's seed is
+// folded in exactly once, whether or not Parallel() is turned on. Using a
+// non-identity seed (100, with addition) catches the regression where
+// parallelAggregate seeded every chunk's reduction and then seeded the
+// combine step again, inflating the result by a multiple of seed.
+func TestParallelAggregateSeedAppliedOnce(t *testing.T) {
+	add := func(acc, v interface{}) (interface{}, error) {
+		return acc.(int) + v.(int), nil
+	}
+	values := []interface{}{1, 2, 3, 4}
+	const seed = 100
+	const want = 110 // seed + sum(values)
+
+	sequential, err := From(values).Aggregate(seed, add)
+	if err != nil {
+		t.Fatalf("sequential Aggregate: %v", err)
+	}
+	if sequential != want {
+		t.Fatalf("sequential Aggregate = %v, want %v", sequential, want)
+	}
+
+	parallel, err := From(values).Parallel().WithDegreeOfParallelism(2).Aggregate(seed, add)
+	if err != nil {
+		t.Fatalf("parallel Aggregate: %v", err)
+	}
+	if parallel != want {
+		t.Fatalf("parallel Aggregate = %v, want %v (seed applied more than once)", parallel, want)
+	}
+}
+
+// TestParallelAggregateEmpty checks the degenerate case where no chunks
+// (and so no reductions) are produced: the result should just be seed,
+// matching the sequential path.
+func TestParallelAggregateEmpty(t *testing.T) {
+	add := func(acc, v interface{}) (interface{}, error) {
+		return acc.(int) + v.(int), nil
+	}
+	result, err := From([]interface{}{}).Parallel().Aggregate(100, add)
+	if err != nil {
+		t.Fatalf("parallel Aggregate on empty input: %v", err)
+	}
+	if result != 100 {
+		t.Fatalf("parallel Aggregate on empty input = %v, want 100", result)
+	}
+}
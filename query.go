@@ -0,0 +1,191 @@
+package linq
+
+import "sort"
+
+// Query is the generics-based counterpart to Queryable, and the core
+// that Queryable's own eager-path operators (Where, Select, OrderBy,
+// Count, First - see Queryable.asQuery) are implemented on top of. It
+// trades the untyped []interface{} pipeline for one parameterized on the
+// element type, so predicates and projections work directly on T instead
+// of requiring a type assertion on every call.
+//
+// Queryable's lazy iterator chaining (FromChannel, FromIterator) and its
+// Parallel() fan-out mode have no generic counterpart here - those stay
+// Queryable-specific layers on top of the shared core rather than being
+// pushed down into Query[T].
+type Query[T any] struct {
+	values []T
+	err    error
+}
+
+// FromSlice builds a Query[T] from a typed slice. Go doesn't allow
+// overloading the package-level From by type parameters alone, so the
+// generic entry point gets its own name; unlike From (which copies every
+// element into a []interface{} for Queryable), this is a zero-copy wrap - no conversion
+// to interface{} happens until the caller asks for it.
+func FromSlice[T any](s []T) Query[T] {
+	return Query[T]{values: s}
+}
+
+// Results returns the underlying values, or the first error recorded by
+// the pipeline.
+func (q Query[T]) Results() ([]T, error) {
+	return q.values, q.err
+}
+
+// ToSlice is an alias for Results kept for symmetry with Queryable's
+// reflection-based ToSlice(out interface{}) — here the type parameter
+// already pins the destination type, so it just returns the slice.
+func (q Query[T]) ToSlice() ([]T, error) {
+	return q.Results()
+}
+
+// Where filters q to the elements for which f returns true.
+func (q Query[T]) Where(f func(T) bool) (r Query[T]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if f == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	for _, v := range q.values {
+		if f(v) {
+			r.values = append(r.values, v)
+		}
+	}
+	return
+}
+
+// WhereErr is the error-returning variant of Where, for predicates that
+// can fail.
+func (q Query[T]) WhereErr(f func(T) (bool, error)) (r Query[T]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if f == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	for _, v := range q.values {
+		ok, err := f(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		if ok {
+			r.values = append(r.values, v)
+		}
+	}
+	return
+}
+
+// Select projects every element of a Query[T] into a Query[U]. It is a
+// package-level function, not a method, because Go methods cannot
+// introduce new type parameters.
+func Select[T, U any](q Query[T], f func(T) U) (r Query[U]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if f == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	r.values = make([]U, len(q.values))
+	for i, v := range q.values {
+		r.values[i] = f(v)
+	}
+	return
+}
+
+// SelectErr is the error-returning variant of Select.
+func SelectErr[T, U any](q Query[T], f func(T) (U, error)) (r Query[U]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if f == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	r.values = make([]U, 0, len(q.values))
+	for _, v := range q.values {
+		u, err := f(v)
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.values = append(r.values, u)
+	}
+	return
+}
+
+// Grouping is a single GroupBy bucket: every element of Values produced the
+// same Key.
+type Grouping[K comparable, T any] struct {
+	Key    K
+	Values []T
+}
+
+// GroupBy partitions q's elements by the key f extracts, preserving the
+// order in which each key first appears.
+func GroupBy[T any, K comparable](q Query[T], f func(T) K) (r Query[Grouping[K, T]]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if f == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	index := make(map[K]int)
+	for _, v := range q.values {
+		k := f(v)
+		if i, ok := index[k]; ok {
+			r.values[i].Values = append(r.values[i].Values, v)
+			continue
+		}
+		index[k] = len(r.values)
+		r.values = append(r.values, Grouping[K, T]{Key: k, Values: []T{v}})
+	}
+	return
+}
+
+// OrderBy sorts q using less as the "this should sort before that"
+// comparison. It is a stable sort, matching sort.SliceStable.
+func (q Query[T]) OrderBy(less func(this, that T) bool) (r Query[T]) {
+	if q.err != nil {
+		r.err = q.err
+		return
+	}
+	if less == nil {
+		r.err = ErrNilFunc
+		return
+	}
+	r.values = make([]T, len(q.values))
+	copy(r.values, q.values)
+	sort.SliceStable(r.values, func(i, j int) bool { return less(r.values[i], r.values[j]) })
+	return
+}
+
+// First returns the first element of q, or ErrNoElement if q is empty.
+func (q Query[T]) First() (elem T, err error) {
+	if q.err != nil {
+		err = q.err
+		return
+	}
+	if len(q.values) == 0 {
+		err = ErrNoElement
+		return
+	}
+	elem = q.values[0]
+	return
+}
+
+// Count returns the number of elements in q.
+func (q Query[T]) Count() (int, error) {
+	return len(q.values), q.err
+}
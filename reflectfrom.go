@@ -0,0 +1,81 @@
+package linq
+
+import "reflect"
+
+// KeyValue is one entry of a map passed to From, with Key and Value
+// holding the original key/value pair as interface{}.
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// FromChannelOf lazily wraps a reflect.Value of Kind Chan as a Queryable,
+// receiving elements of whatever element type the channel carries. It
+// backs the <-chan T case of From; most callers should just use
+// FromChannel(<-chan interface{}) or From directly.
+func FromChannelOf(c reflect.Value) Queryable {
+	return FromIterator(func() (interface{}, bool, error) {
+		v, ok := c.Recv()
+		if !ok {
+			return nil, false, nil
+		}
+		return v.Interface(), true, nil
+	})
+}
+
+// ToSlice populates out, which must be a non-nil pointer to a slice,
+// with q's elements via reflection - the mirror image of From(interface{}).
+// An element converts only if it's assignable to the slice's element type,
+// or both are numeric kinds (so int->float64 widening works); anything
+// else - notably a numeric type going into string, which Go's Convert
+// would silently turn into a rune conversion - produces an error rather
+// than a partially-filled destination.
+func (q Queryable) ToSlice(out interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() || outVal.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDestination
+	}
+
+	m := q.materialize()
+	if m.err != nil {
+		return m.err
+	}
+
+	sliceType := outVal.Elem().Type()
+	elemType := sliceType.Elem()
+	result := reflect.MakeSlice(sliceType, len(m.values), len(m.values))
+	for i, v := range m.values {
+		rv := reflect.ValueOf(v)
+		switch {
+		case v == nil:
+			// leave the zero value for this element
+		case rv.Type().AssignableTo(elemType):
+			result.Index(i).Set(rv)
+		case isNumericKind(rv.Kind()) && isNumericKind(elemType.Kind()):
+			result.Index(i).Set(rv.Convert(elemType))
+		default:
+			return ErrUnsupportedType
+		}
+	}
+	outVal.Elem().Set(result)
+	return nil
+}
+
+// isNumericKind reports whether k is one of the built-in integer or
+// floating-point kinds, used by ToSlice to allow numeric widening (e.g.
+// int -> float64) without also allowing reflect.Value.Convert's
+// numeric-to-string rune conversion.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}